@@ -0,0 +1,53 @@
+package pbkdf2key
+
+import (
+	"crypto/rand"
+	"hash"
+	"time"
+)
+
+// calibrationIterations is the iteration count used for the short
+// measurement run that CalibrateIterations extrapolates from.
+const calibrationIterations = 10000
+
+// CalibrateIterations measures the throughput of a short PBKDF2 run with h
+// and keyLen on the current machine, then extrapolates linearly to return
+// the iteration count whose expected wall time matches target.
+//
+// CalibrateIterations is equivalent to CalibrateIterationsN with a single
+// sample; use CalibrateIterationsN directly to average multiple samples
+// and reduce jitter.
+func CalibrateIterations(h func() hash.Hash, keyLen int, target time.Duration) int {
+	return CalibrateIterationsN(h, keyLen, target, 1)
+}
+
+// CalibrateIterationsN is like CalibrateIterations but averages samples
+// independent measurement runs before extrapolating, which reduces jitter
+// from scheduling noise on the current machine.
+func CalibrateIterationsN(h func() hash.Hash, keyLen int, target time.Duration, samples int) int {
+	if samples < 1 {
+		samples = 1
+	}
+
+	password := []byte("pbkdf2key-calibration-password")
+	salt := make([]byte, 16)
+	rand.Read(salt)
+
+	var total time.Duration
+	for i := 0; i < samples; i++ {
+		start := time.Now()
+		Key(password, salt, calibrationIterations, keyLen, h)
+		total += time.Since(start)
+	}
+
+	perIteration := total / time.Duration(samples*calibrationIterations)
+	if perIteration <= 0 {
+		return calibrationIterations
+	}
+
+	iterations := int(target / perIteration)
+	if iterations < 1 {
+		iterations = 1
+	}
+	return iterations
+}
@@ -0,0 +1,21 @@
+package pbkdf2key
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+)
+
+func TestCalibrateIterationsPositive(t *testing.T) {
+	iter := CalibrateIterations(sha256.New, 32, 10*time.Millisecond)
+	if iter < 1 {
+		t.Fatalf("CalibrateIterations = %d, want >= 1", iter)
+	}
+}
+
+func TestCalibrateIterationsNAveragesSamples(t *testing.T) {
+	iter := CalibrateIterationsN(sha256.New, 32, 10*time.Millisecond, 3)
+	if iter < 1 {
+		t.Fatalf("CalibrateIterationsN = %d, want >= 1", iter)
+	}
+}
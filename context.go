@@ -0,0 +1,75 @@
+package pbkdf2key
+
+import (
+	"context"
+	"crypto/hmac"
+	"hash"
+)
+
+// contextCheckInterval is how often, in inner PBKDF2 iterations, KeyContext
+// checks ctx.Err() while hashing a single block.
+const contextCheckInterval = 1024
+
+// KeyContext is like Key, but checks ctx every contextCheckInterval inner
+// iterations and returns ctx.Err() as soon as it is non-nil, and invokes
+// progress(done, total) after each completed block if progress is non-nil.
+func KeyContext(ctx context.Context, password, salt []byte, iter, keyLen int, h func() hash.Hash, progress func(done, total int)) ([]byte, error) {
+	out := make([]byte, keyLen)
+
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+	tailLen := keyLen - (numBlocks-1)*hashLen
+
+	var buf [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	U := make([]byte, hashLen)
+
+	for block := 1; block <= numBlocks; block++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// N.B.: || means concatenation, ^ means XOR
+		// for each block T_i = U_1 ^ U_2 ^ ... ^ U_iter
+		// U_1 = PRF(password, salt || uint(i))
+		prf.Reset()
+		prf.Write(salt)
+		buf[0] = byte(block >> 24)
+		buf[1] = byte(block >> 16)
+		buf[2] = byte(block >> 8)
+		buf[3] = byte(block)
+		prf.Write(buf[:4])
+		dk = prf.Sum(dk)
+		T := dk[len(dk)-hashLen:]
+		copy(U, T)
+
+		// U_n = PRF(password, U_(n-1))
+		for n := 2; n <= iter; n++ {
+			if n%contextCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			prf.Reset()
+			prf.Write(U)
+			U = U[:0]
+			U = prf.Sum(U)
+			for x := range U {
+				T[x] ^= U[x]
+			}
+		}
+
+		startIndex := (block - 1) * hashLen
+		if block == numBlocks {
+			copy(out[startIndex:], T[0:tailLen])
+		} else {
+			copy(out[startIndex:], T[0:hashLen])
+		}
+
+		if progress != nil {
+			progress(block, numBlocks)
+		}
+	}
+	return out[:keyLen], nil
+}
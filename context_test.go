@@ -0,0 +1,56 @@
+package pbkdf2key
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestKeyContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := KeyContext(ctx, []byte("pw"), []byte("salt"), 1000000, 32, sha256.New, nil)
+	if err != context.Canceled {
+		t.Fatalf("KeyContext with a cancelled context returned err = %v, want context.Canceled", err)
+	}
+}
+
+func TestKeyContextProgress(t *testing.T) {
+	var done []int
+	total := -1
+
+	dk, err := KeyContext(context.Background(), []byte("pw"), []byte("salt"), 10, 100, sha256.New, func(d, t int) {
+		done = append(done, d)
+		total = t
+	})
+	if err != nil {
+		t.Fatalf("KeyContext: %v", err)
+	}
+	if len(dk) != 100 {
+		t.Fatalf("len(dk) = %d, want 100", len(dk))
+	}
+	if total != 4 {
+		t.Fatalf("progress total = %d, want 4 blocks for a 100-byte key with sha256", total)
+	}
+	if len(done) != total {
+		t.Fatalf("progress called %d times, want %d", len(done), total)
+	}
+	for i, d := range done {
+		if d != i+1 {
+			t.Fatalf("progress done sequence = %v, want 1..%d in order", done, total)
+		}
+	}
+}
+
+func TestKeyMatchesKeyContext(t *testing.T) {
+	password, salt := []byte("pw"), []byte("salt")
+	want, err := KeyContext(context.Background(), password, salt, 100, 48, sha256.New, nil)
+	if err != nil {
+		t.Fatalf("KeyContext: %v", err)
+	}
+	got := Key(password, salt, 100, 48, sha256.New)
+	if string(got) != string(want) {
+		t.Fatalf("Key = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package pbkdf2key
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+)
+
+// javaHashFuncs maps the algorithm strings used by Java's
+// javax.crypto.SecretKeyFactory (e.g. "PBKDF2WithHmacSHA256") to the
+// corresponding hash.Hash constructor.
+var javaHashFuncs = map[string]func() hash.Hash{
+	"PBKDF2WithHmacSHA1":   sha1.New,
+	"PBKDF2WithHmacSHA224": sha256.New224,
+	"PBKDF2WithHmacSHA256": sha256.New,
+	"PBKDF2WithHmacSHA384": sha512.New384,
+	"PBKDF2WithHmacSHA512": sha512.New,
+}
+
+// PBEKeySpec mirrors javax.crypto.spec.PBEKeySpec: it carries the
+// parameters needed to derive a password-based key. KeyLength is in bits,
+// matching the Java API, not bytes.
+type PBEKeySpec struct {
+	Password       []byte
+	Salt           []byte
+	IterationCount int
+	KeyLength      int
+}
+
+// SecretKey mirrors javax.crypto.SecretKey as returned by
+// SecretKeyFactory.GenerateSecret: the derived key material together with
+// the parameters it was derived with.
+type SecretKey struct {
+	algorithm string
+	encoded   []byte
+	salt      []byte
+	iterCount int
+	keyLength int
+}
+
+// Algorithm returns the Java-style algorithm name the key was derived
+// with, e.g. "PBKDF2WithHmacSHA256".
+func (k *SecretKey) Algorithm() string { return k.algorithm }
+
+// Encoded returns the derived key bytes.
+func (k *SecretKey) Encoded() []byte { return k.encoded }
+
+// Salt returns the salt the key was derived with.
+func (k *SecretKey) Salt() []byte { return k.salt }
+
+// IterationCount returns the PBKDF2 iteration count the key was derived
+// with.
+func (k *SecretKey) IterationCount() int { return k.iterCount }
+
+// KeyLength returns the key length in bits, matching the Java API.
+func (k *SecretKey) KeyLength() int { return k.keyLength }
+
+// SecretKeyFactory mirrors javax.crypto.SecretKeyFactory: it derives
+// SecretKey values for a single fixed algorithm, obtained via
+// NewSecretKeyFactory.
+type SecretKeyFactory struct {
+	algorithm string
+	h         func() hash.Hash
+}
+
+// NewSecretKeyFactory mirrors SecretKeyFactory.getInstance(algorithm): it
+// looks algorithm up in the Java algorithm registry and returns a factory
+// bound to the corresponding hash function. Supported algorithm strings
+// are "PBKDF2WithHmacSHA1", "PBKDF2WithHmacSHA224", "PBKDF2WithHmacSHA256",
+// "PBKDF2WithHmacSHA384" and "PBKDF2WithHmacSHA512".
+func NewSecretKeyFactory(algorithm string) (*SecretKeyFactory, error) {
+	h, ok := javaHashFuncs[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("pbkdf2key: unsupported algorithm %q", algorithm)
+	}
+	return &SecretKeyFactory{algorithm: algorithm, h: h}, nil
+}
+
+// GenerateSecret mirrors SecretKeyFactory.generateSecret(spec): it derives
+// a SecretKey from spec using the factory's algorithm.
+func (f *SecretKeyFactory) GenerateSecret(spec PBEKeySpec) (*SecretKey, error) {
+	if spec.KeyLength <= 0 || spec.KeyLength%8 != 0 {
+		return nil, fmt.Errorf("pbkdf2key: key length %d is not a whole number of bytes", spec.KeyLength)
+	}
+
+	keyLen := spec.KeyLength / 8
+	encoded := Key(spec.Password, spec.Salt, spec.IterationCount, keyLen, f.h)
+
+	return &SecretKey{
+		algorithm: f.algorithm,
+		encoded:   encoded,
+		salt:      spec.Salt,
+		iterCount: spec.IterationCount,
+		keyLength: spec.KeyLength,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package pbkdf2key
+
+import "testing"
+
+func TestNewSecretKeyFactoryUnsupportedAlgorithm(t *testing.T) {
+	_, err := NewSecretKeyFactory("PBKDF2WithHmacMD5")
+	if err == nil {
+		t.Fatal("expected an error for an unsupported algorithm")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	f, err := NewSecretKeyFactory("PBKDF2WithHmacSHA256")
+	if err != nil {
+		t.Fatalf("NewSecretKeyFactory: %v", err)
+	}
+
+	spec := PBEKeySpec{
+		Password:       []byte("pw"),
+		Salt:           []byte("salt"),
+		IterationCount: 1000,
+		KeyLength:      256,
+	}
+	key, err := f.GenerateSecret(spec)
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+
+	if key.Algorithm() != "PBKDF2WithHmacSHA256" {
+		t.Errorf("Algorithm() = %q, want %q", key.Algorithm(), "PBKDF2WithHmacSHA256")
+	}
+	if len(key.Encoded()) != 32 {
+		t.Errorf("len(Encoded()) = %d, want 32 bytes for a 256-bit key", len(key.Encoded()))
+	}
+	if key.KeyLength() != 256 {
+		t.Errorf("KeyLength() = %d, want 256", key.KeyLength())
+	}
+	if key.IterationCount() != 1000 {
+		t.Errorf("IterationCount() = %d, want 1000", key.IterationCount())
+	}
+	if string(key.Salt()) != "salt" {
+		t.Errorf("Salt() = %q, want %q", key.Salt(), "salt")
+	}
+}
+
+func TestGenerateSecretNonByteAlignedKeyLength(t *testing.T) {
+	f, err := NewSecretKeyFactory("PBKDF2WithHmacSHA256")
+	if err != nil {
+		t.Fatalf("NewSecretKeyFactory: %v", err)
+	}
+
+	_, err = f.GenerateSecret(PBEKeySpec{
+		Password:       []byte("pw"),
+		Salt:           []byte("salt"),
+		IterationCount: 1000,
+		KeyLength:      250,
+	})
+	if err == nil {
+		t.Fatal("expected an error for a key length that is not a whole number of bytes")
+	}
+}
@@ -0,0 +1,98 @@
+package pbkdf2key
+
+import (
+	"crypto/hmac"
+	"hash"
+	"sync"
+)
+
+// KeyParallel derives a key the same way Key does, but splits the
+// independent outer PBKDF2 blocks T_1..T_numBlocks across workers
+// goroutines instead of computing them one at a time. Each worker owns its
+// own hmac.New(h, password) PRF instance and scratch buffers and writes
+// only the bytes for the blocks it owns into the correct offset of the
+// output slice, so no synchronization is needed beyond waiting for all
+// workers to finish. workers is clamped to the number of blocks, and each
+// worker repeats its own hmac.New(h, password) setup.
+//
+// If workers is 1 or less, KeyParallel falls back to Key. Splitting across
+// workers only has an effect when keyLen spans more than one block, i.e.
+// keyLen > the underlying hash size.
+func KeyParallel(password, salt []byte, iter, keyLen int, h func() hash.Hash, workers int) []byte {
+	if workers <= 1 {
+		return Key(password, salt, iter, keyLen, h)
+	}
+
+	out := make([]byte, keyLen)
+
+	hashLen := h().Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+	if numBlocks == 0 {
+		return out[:0]
+	}
+	tailLen := keyLen - (numBlocks-1)*hashLen
+
+	if workers > numBlocks {
+		workers = numBlocks
+	}
+	blocksPerWorker := (numBlocks + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w*blocksPerWorker + 1
+		if start > numBlocks {
+			break
+		}
+		end := start + blocksPerWorker - 1
+		if end > numBlocks {
+			end = numBlocks
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+
+			prf := hmac.New(h, password)
+			var buf [4]byte
+			dk := make([]byte, 0, hashLen)
+			U := make([]byte, hashLen)
+
+			for block := start; block <= end; block++ {
+				// N.B.: || means concatenation, ^ means XOR
+				// for each block T_i = U_1 ^ U_2 ^ ... ^ U_iter
+				// U_1 = PRF(password, salt || uint(i))
+				prf.Reset()
+				prf.Write(salt)
+				buf[0] = byte(block >> 24)
+				buf[1] = byte(block >> 16)
+				buf[2] = byte(block >> 8)
+				buf[3] = byte(block)
+				prf.Write(buf[:4])
+				dk = prf.Sum(dk[:0])
+				T := dk[len(dk)-hashLen:]
+				copy(U, T)
+
+				// U_n = PRF(password, U_(n-1))
+				for n := 2; n <= iter; n++ {
+					prf.Reset()
+					prf.Write(U)
+					U = U[:0]
+					U = prf.Sum(U)
+					for x := range U {
+						T[x] ^= U[x]
+					}
+				}
+
+				startIndex := (block - 1) * hashLen
+				if block == numBlocks {
+					copy(out[startIndex:], T[0:tailLen])
+				} else {
+					copy(out[startIndex:], T[0:hashLen])
+				}
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return out[:keyLen]
+}
@@ -0,0 +1,40 @@
+package pbkdf2key
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestKeyParallelMatchesKey(t *testing.T) {
+	password := []byte("password")
+	salt := []byte("salt-value")
+
+	for _, keyLen := range []int{1, 16, 32, 100} {
+		for _, workers := range []int{1, 2, 3, 8} {
+			want := Key(password, salt, 100, keyLen, sha256.New)
+			got := KeyParallel(password, salt, 100, keyLen, sha256.New, workers)
+			if !bytes.Equal(want, got) {
+				t.Errorf("keyLen=%d workers=%d: KeyParallel != Key\n got  %x\n want %x", keyLen, workers, got, want)
+			}
+		}
+	}
+}
+
+func TestKeyParallelZeroKeyLen(t *testing.T) {
+	got := KeyParallel([]byte("password"), []byte("salt"), 100, 0, sha256.New, 4)
+	if len(got) != 0 {
+		t.Fatalf("KeyParallel with keyLen=0 = %x, want empty slice", got)
+	}
+}
+
+func TestKeyParallelMoreWorkersThanBlocks(t *testing.T) {
+	password := []byte("password")
+	salt := []byte("salt")
+
+	want := Key(password, salt, 100, 4, sha256.New)
+	got := KeyParallel(password, salt, 100, 4, sha256.New, 64)
+	if !bytes.Equal(want, got) {
+		t.Fatalf("KeyParallel with workers>numBlocks = %x, want %x", got, want)
+	}
+}
@@ -1,7 +1,7 @@
 package pbkdf2key
 
 import (
-	"crypto/hmac"
+	"context"
 	"hash"
 )
 
@@ -23,51 +23,19 @@ import (
 //
 // Using a higher iteration count will increase the cost of an exhaustive
 // search but will also make derivation proportionally slower.
+//
+// Key is a thin wrapper around KeyContext using context.Background(), which
+// never returns an error.
+//
+// Fix: prior to this change, block assembly copied every non-final block
+// into out[0:hashLen] instead of out[startIndex:startIndex+hashLen], so any
+// call with keyLen spanning 3 or more blocks (keyLen > 2*hashLen) produced
+// a key whose middle blocks were overwritten rather than retained — only
+// the first and last blocks of the output were correct. Key's output for
+// such keyLen values now differs from (and corrects) prior versions;
+// anything derived or persisted using keyLen > 2*hashLen before this fix
+// must be re-derived.
 func Key(password, salt []byte, iter, keyLen int, h func() hash.Hash) []byte {
-
-	out := make([]byte, keyLen) //var6
-
-	prf := hmac.New(h, password)
-	hashLen := prf.Size()                         //var7
-	numBlocks := (keyLen + hashLen - 1) / hashLen //var8
-	tailLen := keyLen - (numBlocks-1)*hashLen     //var9
-
-	var buf [4]byte                          //var13
-	dk := make([]byte, 0, numBlocks*hashLen) //var10
-	U := make([]byte, hashLen)               //var11
-
-	for block := 1; block <= numBlocks; block++ {
-		// N.B.: || means concatenation, ^ means XOR
-		// for each block T_i = U_1 ^ U_2 ^ ... ^ U_iter
-		// U_1 = PRF(password, salt || uint(i))
-		prf.Reset()
-		prf.Write(salt)
-		buf[0] = byte(block >> 24)
-		buf[1] = byte(block >> 16)
-		buf[2] = byte(block >> 8)
-		buf[3] = byte(block)
-		prf.Write(buf[:4])
-		dk = prf.Sum(dk)
-		T := dk[len(dk)-hashLen:]
-		copy(U, T)
-
-		// U_n = PRF(password, U_(n-1))
-		for n := 2; n <= iter; n++ {
-			prf.Reset()
-			prf.Write(U)
-			U = U[:0]
-			U = prf.Sum(U)
-			for x := range U {
-				T[x] ^= U[x]
-			}
-		}
-
-		startIndex := (block - 1) * hashLen
-		if block == numBlocks {
-			copy(out[startIndex:], T[0:tailLen])
-		} else {
-			copy(out, T[0:hashLen])
-		}
-	}
-	return out[:keyLen]
+	dk, _ := KeyContext(context.Background(), password, salt, iter, keyLen, h, nil)
+	return dk
 }
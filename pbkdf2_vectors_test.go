@@ -0,0 +1,76 @@
+package pbkdf2key
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"testing"
+
+	xpbkdf2 "golang.org/x/crypto/pbkdf2"
+)
+
+// rfc6070Vectors are the PBKDF2-HMAC-SHA1 test vectors from RFC 6070 §2.
+// They anchor correctness of the underlying primitive independently of
+// this package's own implementation.
+var rfc6070Vectors = []struct {
+	password, salt string
+	iter, keyLen   int
+	dk             string
+}{
+	{"password", "salt", 1, 20, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+	{"password", "salt", 2, 20, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+	{"password", "salt", 4096, 20, "4b007901b765489abead49d926f721d065a429c1"},
+	{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 25, "3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+}
+
+func TestKeyRFC6070Vectors(t *testing.T) {
+	for _, v := range rfc6070Vectors {
+		want, err := hex.DecodeString(v.dk)
+		if err != nil {
+			t.Fatalf("bad test vector hex: %v", err)
+		}
+		got := Key([]byte(v.password), []byte(v.salt), v.iter, v.keyLen, sha1.New)
+		if !bytes.Equal(got, want) {
+			t.Errorf("Key(%q, %q, %d, %d) = %x, want %x", v.password, v.salt, v.iter, v.keyLen, got, want)
+		}
+	}
+}
+
+// TestKeyMultiBlockAgainstReference cross-checks Key/KeyContext against
+// golang.org/x/crypto/pbkdf2 for keyLen spanning 3 or more hash blocks, the
+// exact case in which a prior block-assembly bug (fixed alongside
+// KeyContext) overwrote everything but the first and last block. Unlike a
+// self-consistency check between Key and KeyContext, this compares against
+// an independently implemented PBKDF2.
+func TestKeyMultiBlockAgainstReference(t *testing.T) {
+	cases := []struct {
+		password, salt string
+		iter, keyLen   int
+		h              func() hash.Hash
+	}{
+		{"password", "salt", 1000, 100, sha256.New},    // 4 sha256 blocks
+		{"correct horse battery staple", "a reasonably long salt value", 500, 150, sha512.New}, // 3 sha512 blocks
+		{"p", "s", 10, 63, sha1.New},                   // 4 sha1 blocks, non-aligned tail
+	}
+
+	for _, c := range cases {
+		want := xpbkdf2.Key([]byte(c.password), []byte(c.salt), c.iter, c.keyLen, c.h)
+
+		got := Key([]byte(c.password), []byte(c.salt), c.iter, c.keyLen, c.h)
+		if !bytes.Equal(got, want) {
+			t.Errorf("Key(%q, %q, %d, %d) = %x, want %x", c.password, c.salt, c.iter, c.keyLen, got, want)
+		}
+
+		gotCtx, err := KeyContext(context.Background(), []byte(c.password), []byte(c.salt), c.iter, c.keyLen, c.h, nil)
+		if err != nil {
+			t.Fatalf("KeyContext: %v", err)
+		}
+		if !bytes.Equal(gotCtx, want) {
+			t.Errorf("KeyContext(%q, %q, %d, %d) = %x, want %x", c.password, c.salt, c.iter, c.keyLen, gotCtx, want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package pbkdf2key
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// maxPHCKeyLen bounds the l= field accepted by parsePHC, so that a
+// malformed or adversarial PHC string cannot force Verify into a
+// multi-gigabyte allocation.
+const maxPHCKeyLen = 1 << 20 // 1 MiB, far beyond any realistic PBKDF2 key length
+
+// hashFuncs maps the short hash names used in the PHC string format to the
+// corresponding hash.Hash constructor.
+var hashFuncs = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// Params holds the parameters used to derive and verify a PHC-encoded
+// PBKDF2 hash.
+type Params struct {
+	// Hash is the name of the underlying hash function, one of "sha1",
+	// "sha224", "sha256", "sha384" or "sha512".
+	Hash string
+	// Iterations is the PBKDF2 iteration count.
+	Iterations int
+	// KeyLen is the length in bytes of the derived key.
+	KeyLen int
+	// SaltLen is the length in bytes of the randomly generated salt.
+	SaltLen int
+}
+
+// Hash derives a key from password using PBKDF2 with the given Params and
+// encodes the result in the PHC string format, e.g.:
+//
+//	$pbkdf2-sha256$i=10000,l=32$<b64salt>$<b64hash>
+//
+// The salt is generated using crypto/rand.
+func Hash(password []byte, params Params) (string, error) {
+	h, ok := hashFuncs[params.Hash]
+	if !ok {
+		return "", fmt.Errorf("pbkdf2key: unknown hash %q", params.Hash)
+	}
+
+	salt := make([]byte, params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("pbkdf2key: generating salt: %w", err)
+	}
+
+	dk := Key(password, salt, params.Iterations, params.KeyLen, h)
+
+	return fmt.Sprintf("$pbkdf2-%s$i=%d,l=%d$%s$%s",
+		params.Hash,
+		params.Iterations,
+		params.KeyLen,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(dk),
+	), nil
+}
+
+// Verify parses a PHC-encoded string produced by Hash, re-derives the key
+// from password using the embedded hash, iteration count and salt, and
+// reports whether it matches using a constant-time comparison.
+func Verify(encoded string, password []byte) (bool, error) {
+	hashName, iter, keyLen, salt, want, err := parsePHC(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	h, ok := hashFuncs[hashName]
+	if !ok {
+		return false, fmt.Errorf("pbkdf2key: unknown hash %q", hashName)
+	}
+
+	got := Key(password, salt, iter, keyLen, h)
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
+
+// parsePHC splits a PHC string of the form
+// "$pbkdf2-<hash>$i=<iter>,l=<keylen>$<b64salt>$<b64hash>" into its parts.
+func parsePHC(encoded string) (hashName string, iter, keyLen int, salt, hash []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[0] != "" {
+		return "", 0, 0, nil, nil, errors.New("pbkdf2key: malformed encoded hash")
+	}
+
+	if !strings.HasPrefix(parts[1], "pbkdf2-") {
+		return "", 0, 0, nil, nil, fmt.Errorf("pbkdf2key: unsupported algorithm %q", parts[1])
+	}
+	hashName = strings.TrimPrefix(parts[1], "pbkdf2-")
+
+	for _, field := range strings.Split(parts[2], ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return "", 0, 0, nil, nil, fmt.Errorf("pbkdf2key: malformed parameter %q", field)
+		}
+		switch kv[0] {
+		case "i":
+			iter, err = strconv.Atoi(kv[1])
+		case "l":
+			keyLen, err = strconv.Atoi(kv[1])
+		default:
+			err = fmt.Errorf("pbkdf2key: unknown parameter %q", kv[0])
+		}
+		if err != nil {
+			return "", 0, 0, nil, nil, err
+		}
+	}
+	if iter <= 0 {
+		return "", 0, 0, nil, nil, fmt.Errorf("pbkdf2key: invalid iteration count %d", iter)
+	}
+	if keyLen <= 0 || keyLen > maxPHCKeyLen {
+		return "", 0, 0, nil, nil, fmt.Errorf("pbkdf2key: invalid key length %d", keyLen)
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", 0, 0, nil, nil, fmt.Errorf("pbkdf2key: decoding salt: %w", err)
+	}
+	hash, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return "", 0, 0, nil, nil, fmt.Errorf("pbkdf2key: decoding hash: %w", err)
+	}
+
+	return hashName, iter, keyLen, salt, hash, nil
+}
@@ -0,0 +1,55 @@
+package pbkdf2key
+
+import "testing"
+
+func TestHashVerifyRoundTrip(t *testing.T) {
+	params := Params{Hash: "sha256", Iterations: 1000, KeyLen: 32, SaltLen: 16}
+
+	encoded, err := Hash([]byte("correct horse"), params)
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	ok, err := Verify(encoded, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok {
+		t.Fatal("Verify returned false for the correct password")
+	}
+
+	ok, err = Verify(encoded, []byte("wrong password"))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify returned true for the wrong password")
+	}
+}
+
+func TestHashUnknownHash(t *testing.T) {
+	_, err := Hash([]byte("pw"), Params{Hash: "md5", Iterations: 1000, KeyLen: 32, SaltLen: 16})
+	if err == nil {
+		t.Fatal("expected an error for an unknown hash name")
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	cases := []string{
+		"",
+		"not-a-phc-string",
+		"$pbkdf2-sha256$i=1000,l=32$onlyonefield",
+		"$pbkdf2-sha256$i=notanumber,l=32$c2FsdA$aGFzaA",
+		"$pbkdf2-md5$i=1000,l=32$c2FsdA$aGFzaA",
+		"$pbkdf2-sha256$i=0,l=32$c2FsdA$aGFzaA",
+		"$pbkdf2-sha256$i=-1,l=32$c2FsdA$aGFzaA",
+		"$pbkdf2-sha256$i=1000,l=0$c2FsdA$aGFzaA",
+		"$pbkdf2-sha256$i=1000,l=-5$c2FsdA$aGFzaA",
+		"$pbkdf2-sha256$i=1000,l=999999999$c2FsdA$aGFzaA",
+	}
+	for _, encoded := range cases {
+		if _, err := Verify(encoded, []byte("pw")); err == nil {
+			t.Errorf("Verify(%q, ...) = nil error, want error", encoded)
+		}
+	}
+}